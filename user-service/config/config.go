@@ -2,7 +2,11 @@ package config
 
 import (
 	"errors"
+	"fmt"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/NomadCrew/nomad-crew-backend/user-service/logger"
 )
@@ -12,6 +16,53 @@ type Config struct {
 	JwtSecretKey             string
 	Port                     string
 	serviceAccountKeyPath    string
+	CSRFEnabled              bool
+	CSRFSessionCookie        string
+	Server                   ServerConfig
+	HTTPClient               HTTPClientConfig
+	Swagger                  SwaggerConfig
+	RateLimit                RateLimitConfig
+	Redis                    RedisConfig
+}
+
+// ServerConfig holds the HTTP server timeouts
+type ServerConfig struct {
+	ReadTimeout  time.Duration
+	WriteTimeout time.Duration
+	IdleTimeout  time.Duration
+}
+
+// SwaggerConfig controls serving of the generated API docs. Docs are only served
+// when Enabled, and require basic auth when a username is set.
+type SwaggerConfig struct {
+	Enabled  bool
+	Username string
+	Password string
+}
+
+// RateLimitConfig bounds mutating requests per client. A WritesPerMinute of 0
+// disables the limiter, as does leaving REDIS_ADDR unset. X-Real-IP is only honoured from TrustedProxies.
+type RateLimitConfig struct {
+	WritesPerMinute int
+	Burst           int
+	TrustedProxies  []string
+}
+
+// RedisConfig holds the connection settings for the shared Redis instance
+type RedisConfig struct {
+	Addr     string
+	Password string
+}
+
+// HTTPClientConfig holds retry and timeout settings for calls to third-party APIs
+type HTTPClientConfig struct {
+	MaxRetries int
+	BaseDelay  time.Duration
+	MaxDelay   time.Duration
+	Timeout    time.Duration
+	// RequestDeadline bounds all upstream calls made while serving one request,
+	// retries included, and must be shorter than the server's WriteTimeout
+	RequestDeadline time.Duration
 }
 
 func LoadConfig() (*Config, error) {
@@ -23,6 +74,17 @@ func LoadConfig() (*Config, error) {
 		JwtSecretKey:             os.Getenv("JWT_SECRET_KEY"),
 		Port:                     os.Getenv("PORT"),
 		serviceAccountKeyPath:    os.Getenv("SERVICE_ACCOUNT_KEY_PATH"),
+		CSRFEnabled:              os.Getenv("CSRF_ENABLED") == "true",
+		CSRFSessionCookie:        os.Getenv("CSRF_SESSION_COOKIE"),
+		Redis: RedisConfig{
+			Addr:     os.Getenv("REDIS_ADDR"),
+			Password: os.Getenv("REDIS_PASSWORD"),
+		},
+		Swagger: SwaggerConfig{
+			Enabled:  os.Getenv("SWAGGER_ENABLED") == "true",
+			Username: os.Getenv("SWAGGER_USERNAME"),
+			Password: os.Getenv("SWAGGER_PASSWORD"),
+		},
 	}
 
 	if cfg.DatabaseConnectionString == "" || cfg.JwtSecretKey == "" || cfg.Port == "" {
@@ -30,5 +92,101 @@ func LoadConfig() (*Config, error) {
 		return nil, errors.New("one or more environment variables are not set")
 	}
 
+	if cfg.CSRFSessionCookie == "" {
+		cfg.CSRFSessionCookie = "session"
+	}
+
+	var err error
+	if cfg.Server.ReadTimeout, err = getDurationEnv("SERVER_READ_TIMEOUT", 15*time.Second); err != nil {
+		return nil, err
+	}
+	if cfg.Server.WriteTimeout, err = getDurationEnv("SERVER_WRITE_TIMEOUT", 60*time.Second); err != nil {
+		return nil, err
+	}
+	if cfg.Server.IdleTimeout, err = getDurationEnv("SERVER_IDLE_TIMEOUT", 120*time.Second); err != nil {
+		return nil, err
+	}
+
+	if cfg.Swagger.Username != "" && cfg.Swagger.Password == "" {
+		return nil, errors.New("SWAGGER_PASSWORD must be set when SWAGGER_USERNAME is set")
+	}
+
+	if cfg.HTTPClient.MaxRetries, err = getIntEnv("HTTP_CLIENT_MAX_RETRIES", 3); err != nil {
+		return nil, err
+	}
+	if cfg.HTTPClient.BaseDelay, err = getDurationEnv("HTTP_CLIENT_BASE_DELAY", 200*time.Millisecond); err != nil {
+		return nil, err
+	}
+	if cfg.HTTPClient.MaxDelay, err = getDurationEnv("HTTP_CLIENT_MAX_DELAY", 2*time.Second); err != nil {
+		return nil, err
+	}
+	if cfg.HTTPClient.Timeout, err = getDurationEnv("HTTP_CLIENT_TIMEOUT", 10*time.Second); err != nil {
+		return nil, err
+	}
+	if cfg.HTTPClient.RequestDeadline, err = getDurationEnv("HTTP_CLIENT_REQUEST_DEADLINE", 45*time.Second); err != nil {
+		return nil, err
+	}
+	if cfg.HTTPClient.RequestDeadline >= cfg.Server.WriteTimeout {
+		return nil, fmt.Errorf("HTTP_CLIENT_REQUEST_DEADLINE (%s) must be shorter than SERVER_WRITE_TIMEOUT (%s)", cfg.HTTPClient.RequestDeadline, cfg.Server.WriteTimeout)
+	}
+
+	if cfg.RateLimit.WritesPerMinute, err = getIntEnv("RATE_LIMIT_WRITES_PER_MINUTE", 60); err != nil {
+		return nil, err
+	}
+	if cfg.RateLimit.Burst, err = getIntEnv("RATE_LIMIT_BURST", 10); err != nil {
+		return nil, err
+	}
+	cfg.RateLimit.TrustedProxies = getListEnv("RATE_LIMIT_TRUSTED_PROXIES", []string{"127.0.0.1", "::1"})
+	if cfg.RateLimit.WritesPerMinute > 0 {
+		if cfg.RateLimit.Burst < 1 {
+			return nil, errors.New("RATE_LIMIT_BURST must be at least 1 when rate limiting is enabled")
+		}
+		if cfg.Redis.Addr == "" {
+			log.Warn("REDIS_ADDR is not set; write rate limiting is disabled")
+			cfg.RateLimit.WritesPerMinute = 0
+		}
+	}
+
 	return cfg, nil
 }
+
+// getDurationEnv parses a duration such as "30s" from the environment, falling back when unset
+func getDurationEnv(key string, fallback time.Duration) (time.Duration, error) {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid duration for %s: %q", key, value)
+	}
+	return d, nil
+}
+
+// getIntEnv parses a non-negative integer from the environment, falling back when unset
+func getIntEnv(key string, fallback int) (int, error) {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid integer for %s: %q", key, value)
+	}
+	return n, nil
+}
+
+// getListEnv splits a comma-separated value from the environment, falling back when unset
+func getListEnv(key string, fallback []string) []string {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,22 @@
+package db
+
+import (
+	"context"
+
+	"github.com/NomadCrew/nomad-crew-backend/user-service/logger"
+	"github.com/redis/go-redis/v9"
+)
+
+func ConnectToRedis(addr, password string) *redis.Client {
+	log := logger.GetLogger()
+	client := redis.NewClient(&redis.Options{
+		Addr:     addr,
+		Password: password,
+	})
+	if err := client.Ping(context.Background()).Err(); err != nil {
+		log.Fatalf("Unable to connect to Redis: %v\n", err)
+	} else {
+		log.Printf("Connected to Redis")
+	}
+	return client
+}
@@ -0,0 +1,96 @@
+package errors
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// ErrorType identifies a class of error and is sent to clients as the "code" field
+type ErrorType string
+
+const (
+	ValidationError     ErrorType = "VALIDATION_ERROR"
+	AuthError           ErrorType = "AUTHENTICATION_ERROR"
+	ForbiddenError      ErrorType = "FORBIDDEN"
+	NotFoundError       ErrorType = "NOT_FOUND"
+	MethodNotAllowedErr ErrorType = "METHOD_NOT_ALLOWED"
+	ServerError         ErrorType = "SERVER_ERROR"
+	ExternalServiceErr  ErrorType = "EXTERNAL_SERVICE_ERROR"
+	RateLimitError      ErrorType = "RATE_LIMITED"
+)
+
+// AppError is an error carrying the type and HTTP status it should be reported with
+type AppError struct {
+	Type       ErrorType
+	Message    string
+	Detail     string
+	HTTPStatus int
+}
+
+func (e *AppError) Error() string {
+	if e.Detail != "" {
+		return fmt.Sprintf("%s: %s (%s)", e.Type, e.Message, e.Detail)
+	}
+	return fmt.Sprintf("%s: %s", e.Type, e.Message)
+}
+
+// ErrorResponse is the JSON envelope written for every error response
+type ErrorResponse struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	Details string `json:"details,omitempty"`
+}
+
+func New(errType ErrorType, message, detail string, status int) *AppError {
+	return &AppError{Type: errType, Message: message, Detail: detail, HTTPStatus: status}
+}
+
+func ValidationFailed(message, detail string) *AppError {
+	return New(ValidationError, message, detail, http.StatusBadRequest)
+}
+
+func Unauthorized(message, detail string) *AppError {
+	return New(AuthError, message, detail, http.StatusUnauthorized)
+}
+
+func Forbidden(message, detail string) *AppError {
+	return New(ForbiddenError, message, detail, http.StatusForbidden)
+}
+
+func NotFound(message, detail string) *AppError {
+	return New(NotFoundError, message, detail, http.StatusNotFound)
+}
+
+func MethodNotAllowed() *AppError {
+	return New(MethodNotAllowedErr, "Method not allowed", "", http.StatusMethodNotAllowed)
+}
+
+func InternalServerError(message string) *AppError {
+	return New(ServerError, message, "", http.StatusInternalServerError)
+}
+
+func ExternalServiceError(message string) *AppError {
+	return New(ExternalServiceErr, message, "", http.StatusBadGateway)
+}
+
+func RateLimited(message string) *AppError {
+	return New(RateLimitError, message, "", http.StatusTooManyRequests)
+}
+
+// WriteError writes err as an ErrorResponse. Errors that are not an AppError are
+// reported as a generic server error so internal details are not leaked.
+func WriteError(w http.ResponseWriter, err error) {
+	appErr, ok := err.(*AppError)
+	if !ok {
+		appErr = InternalServerError("An unexpected error occurred")
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(appErr.HTTPStatus)
+	json.NewEncoder(w).Encode(ErrorResponse{
+		Code:    string(appErr.Type),
+		Message: appErr.Message,
+		Details: appErr.Detail,
+	})
+}
@@ -1,18 +1,28 @@
 package handlers
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
+	"time"
 
+	"github.com/NomadCrew/nomad-crew-backend/user-service/errors"
+	"github.com/NomadCrew/nomad-crew-backend/user-service/httpclient"
+	"github.com/NomadCrew/nomad-crew-backend/user-service/logger"
 	"github.com/NomadCrew/nomad-crew-backend/user-service/models"
 	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/redis/go-redis/v9"
 )
 
 type Server struct {
-	DB *pgxpool.Pool
+	DB         *pgxpool.Pool
+	Redis      *redis.Client // nil when REDIS_ADDR is unset
+	HTTPClient *httpclient.Client
+	// UpstreamDeadline caps the total time a handler spends on third-party calls
+	UpstreamDeadline time.Duration
 }
 
 // RegisterHandler godoc
@@ -21,25 +31,30 @@ type Server struct {
 // @Tags users
 // @Accept json
 // @Produce json
-// @Param user body User true "Create user"
-// @Success 200 {object} User
-// @Router /register [post]
+// @Param user body models.User true "Create user"
+// @Success 201 {object} models.User
+// @Failure 400 {object} errors.ErrorResponse
+// @Failure 429 {object} errors.ErrorResponse
+// @Failure 500 {object} errors.ErrorResponse
+// @Router /v1/register [post]
 func (s *Server) RegisterHandler(w http.ResponseWriter, r *http.Request) {
+	log := logger.GetLogger()
 	if r.Method != "POST" {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		errors.WriteError(w, errors.MethodNotAllowed())
 		return
 	}
 
 	var u models.User
 	err := json.NewDecoder(r.Body).Decode(&u)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		errors.WriteError(w, errors.ValidationFailed("Invalid request body", err.Error()))
 		return
 	}
 
 	ctx := r.Context()
 	if err := u.SaveUser(ctx, s.DB); err != nil {
-		http.Error(w, "Failed to save user", http.StatusInternalServerError)
+		log.Errorf("Failed to save user: %v", err)
+		errors.WriteError(w, errors.InternalServerError("Failed to save user"))
 		return
 	}
 
@@ -49,13 +64,13 @@ func (s *Server) RegisterHandler(w http.ResponseWriter, r *http.Request) {
 
 // LoginHandler godoc
 // @Summary Login user
-// @Description Login a user with email and password
+// @Description Placeholder; authentication is handled client-side by Firebase
 // @Tags users
 // @Accept  json
 // @Produce  json
-// @Param credentials body Credentials true "User credentials"
-// @Success 200 {object} User
-// @Router /login [post]
+// @Success 200 {string} string
+// @Failure 429 {object} errors.ErrorResponse
+// @Router /v1/login [post]
 func (s *Server) LoginHandler(w http.ResponseWriter, r *http.Request) {
 	// Implement the functinality to authenticate and assing a custom JWT token
 	// if you decide to move away from firebase auth on client-side. Otherwise, this is just a placeholder.
@@ -64,15 +79,82 @@ func (s *Server) LoginHandler(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode("Logged in successfully")
 }
 
+// readinessTimeout bounds each dependency ping so a Neon cold start fails the probe fast
+const readinessTimeout = time.Second
+
+// HealthHandler reports readiness: the process is up and the database (and Redis,
+// when configured) answer a ping
+// @Summary Readiness probe
+// @Tags health
+// @Produce json
+// @Success 200 {object} map[string]string
+// @Failure 503 {object} map[string]string
+// @Router /health [get]
+// @Router /health/ready [get]
 func (s *Server) HealthHandler(w http.ResponseWriter, r *http.Request) {
+	checks := map[string]func(context.Context) error{
+		"database": s.DB.Ping,
+	}
+	if s.Redis != nil {
+		checks["redis"] = func(ctx context.Context) error { return s.Redis.Ping(ctx).Err() }
+	}
+	writeReadiness(w, r, checks)
+}
+
+// writeReadiness runs each named check and writes a per-dependency status map,
+// with 503 if any check fails
+func writeReadiness(w http.ResponseWriter, r *http.Request, checks map[string]func(context.Context) error) {
+	log := logger.GetLogger()
+	status := http.StatusOK
+	resp := map[string]string{"status": "ok"}
+	for name, check := range checks {
+		ctx, cancel := context.WithTimeout(r.Context(), readinessTimeout)
+		err := check(ctx)
+		cancel()
+		if err != nil {
+			log.Warnf("Readiness check %s failed: %v", name, err)
+			status = http.StatusServiceUnavailable
+			resp[name] = "down"
+			continue
+		}
+		resp[name] = "ok"
+	}
+	if status != http.StatusOK {
+		resp["status"] = "unavailable"
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// LivenessHandler reports that the process is up without checking dependencies
+// @Summary Liveness probe
+// @Tags health
+// @Produce json
+// @Success 200 {object} map[string]string
+// @Router /health/live [get]
+func (s *Server) LivenessHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
 	jsonResp, _ := json.Marshal(map[string]string{"status": "ok"})
 	_, _ = w.Write(jsonResp)
 }
 
+// GetUserHandler godoc
+// @Summary Get current user
+// @Description Get the profile of the authenticated user
+// @Tags users
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} models.User
+// @Failure 401 {object} errors.ErrorResponse
+// @Failure 500 {object} errors.ErrorResponse
+// @Router /v1/user [get]
 func (s *Server) GetUserHandler(w http.ResponseWriter, r *http.Request) {
+	log := logger.GetLogger()
 	claims := r.Context().Value("userInfo")
 	if claims == nil {
-		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		errors.WriteError(w, errors.Unauthorized("Unauthorized", ""))
 		return
 	}
 
@@ -82,7 +164,8 @@ func (s *Server) GetUserHandler(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	u, err := models.GetUserByEmail(ctx, s.DB, email)
 	if err != nil {
-		http.Error(w, "Failed to get user", http.StatusInternalServerError)
+		log.Errorf("Failed to get user: %v", err)
+		errors.WriteError(w, errors.InternalServerError("Failed to get user"))
 		return
 	}
 
@@ -90,18 +173,32 @@ func (s *Server) GetUserHandler(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(u)
 }
 
+// GetNearbyPlacesHandler godoc
+// @Summary Nearby places
+// @Description List activity, nature and leisure places within 15km of a point
+// @Tags places
+// @Produce json
+// @Security BearerAuth
+// @Param lat query string true "Latitude"
+// @Param lon query string true "Longitude"
+// @Success 200 {array} object
+// @Failure 400 {object} errors.ErrorResponse
+// @Failure 502 {object} errors.ErrorResponse
+// @Router /v1/nearby-places [get]
 func (s *Server) GetNearbyPlacesHandler(w http.ResponseWriter, r *http.Request) {
+	log := logger.GetLogger()
 	lat := r.URL.Query().Get("lat")
 	lon := r.URL.Query().Get("lon")
 
 	if lat == "" || lon == "" {
-		http.Error(w, "Missing latitude or longitude", http.StatusBadRequest)
+		errors.WriteError(w, errors.ValidationFailed("Missing latitude or longitude", ""))
 		return
 	}
 
 	GEOAPIFY_KEY := os.Getenv("GEOAPIFY_KEY")
 	if GEOAPIFY_KEY == "" {
-		http.Error(w, "GEOAPIFY_KEY environment variable not set", http.StatusInternalServerError)
+		log.Error("GEOAPIFY_KEY environment variable not set")
+		errors.WriteError(w, errors.InternalServerError("Places search is not configured"))
 		return
 	}
 	baseURL := "https://api.geoapify.com/v2/places"
@@ -111,27 +208,40 @@ func (s *Server) GetNearbyPlacesHandler(w http.ResponseWriter, r *http.Request)
 
 	url := fmt.Sprintf("%s?categories=%s&filter=%s&limit=%d&apiKey=%s", baseURL, categories, filter, limit, GEOAPIFY_KEY)
 
+	// Both upstream calls, retries included, share one deadline so the error
+	// response is written before the server's write timeout cuts the connection
+	ctx, cancel := context.WithTimeout(r.Context(), s.UpstreamDeadline)
+	defer cancel()
+
 	// Create the HTTP request
-	client := &http.Client{}
-	req, err := http.NewRequest("GET", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		log.Errorf("Failed to build Geoapify request: %v", err)
+		errors.WriteError(w, errors.InternalServerError("Failed to search nearby places"))
 		return
 	}
 	req.Header.Add("Content-Type", "application/json")
 
 	// Send the request to Geoapify
-	res, err := client.Do(req)
+	res, err := s.HTTPClient.Do(req)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		log.Errorf("Geoapify request failed: %v", err)
+		errors.WriteError(w, errors.ExternalServiceError("Failed to search nearby places"))
 		return
 	}
 	defer res.Body.Close()
 
+	if res.StatusCode != http.StatusOK {
+		log.Errorf("Geoapify returned status %d", res.StatusCode)
+		errors.WriteError(w, errors.ExternalServiceError("Failed to search nearby places"))
+		return
+	}
+
 	// Read the response
 	body, err := io.ReadAll(res.Body)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		log.Errorf("Failed to read Geoapify response: %v", err)
+		errors.WriteError(w, errors.ExternalServiceError("Failed to search nearby places"))
 		return
 	}
 
@@ -150,15 +260,16 @@ func (s *Server) GetNearbyPlacesHandler(w http.ResponseWriter, r *http.Request)
 		} `json:"features"`
 	}
 	if err := json.Unmarshal(body, &geoapifyResponse); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		log.Errorf("Failed to decode Geoapify response: %v", err)
+		errors.WriteError(w, errors.ExternalServiceError("Failed to search nearby places"))
 		return
 	}
 
-	// Fetch the default image from Pexels
-	defaultImage, err := fetchDefaultImage()
+	// Fetch the default image from Pexels; places are still useful without it
+	defaultImage, err := s.fetchDefaultImage(ctx)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
+		log.Warnf("Failed to fetch default image, returning places without one: %v", err)
+		defaultImage = ""
 	}
 
 	// Prepare the response
@@ -181,26 +292,29 @@ func (s *Server) GetNearbyPlacesHandler(w http.ResponseWriter, r *http.Request)
 	json.NewEncoder(w).Encode(nearbyPlaces)
 }
 
-func fetchDefaultImage() (string, error) {
+func (s *Server) fetchDefaultImage(ctx context.Context) (string, error) {
 	pexelsAPIKey := os.Getenv("PEXELS_API_KEY")
 	if pexelsAPIKey == "" {
 		return "", fmt.Errorf("PEXELS_API_KEY is not set")
 	}
 
 	url := "https://api.pexels.com/v1/search?query=park&per_page=1"
-	req, err := http.NewRequest("GET", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return "", err
 	}
 
 	req.Header.Set("Authorization", pexelsAPIKey)
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	resp, err := s.HTTPClient.Do(req)
 	if err != nil {
 		return "", err
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("pexels API error: status %d", resp.StatusCode)
+	}
+
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
 		return "", err
@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+func TestWriteReadiness(t *testing.T) {
+	up := func(context.Context) error { return nil }
+
+	mr := miniredis.RunT(t)
+	liveRedis := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+
+	closed := miniredis.RunT(t)
+	deadRedis := redis.NewClient(&redis.Options{Addr: closed.Addr(), MaxRetries: -1})
+	closed.Close()
+
+	tests := []struct {
+		name       string
+		redis      *redis.Client
+		wantStatus int
+		want       map[string]string
+	}{
+		{
+			name:       "all dependencies up",
+			redis:      liveRedis,
+			wantStatus: http.StatusOK,
+			want:       map[string]string{"status": "ok", "database": "ok", "redis": "ok"},
+		},
+		{
+			name:       "redis down",
+			redis:      deadRedis,
+			wantStatus: http.StatusServiceUnavailable,
+			want:       map[string]string{"status": "unavailable", "database": "ok", "redis": "down"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checks := map[string]func(context.Context) error{
+				"database": up,
+				"redis":    func(ctx context.Context) error { return tt.redis.Ping(ctx).Err() },
+			}
+
+			rec := httptest.NewRecorder()
+			writeReadiness(rec, httptest.NewRequest(http.MethodGet, "/health/ready", nil), checks)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			var got map[string]string
+			if err := json.NewDecoder(rec.Body).Decode(&got); err != nil {
+				t.Fatal(err)
+			}
+			for k, v := range tt.want {
+				if got[k] != v {
+					t.Errorf("%s = %q, want %q", k, got[k], v)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+
+	"github.com/NomadCrew/nomad-crew-backend/user-service/config"
+	"github.com/NomadCrew/nomad-crew-backend/user-service/logger"
+)
+
+// Client wraps http.Client with bounded retries and exponential backoff for
+// transient upstream failures (network errors, timeouts and 5xx responses)
+type Client struct {
+	http       *http.Client
+	maxRetries int
+	baseDelay  time.Duration
+	maxDelay   time.Duration
+}
+
+func New(cfg config.HTTPClientConfig) *Client {
+	return &Client{
+		http:       &http.Client{Timeout: cfg.Timeout},
+		maxRetries: cfg.MaxRetries,
+		baseDelay:  cfg.BaseDelay,
+		maxDelay:   cfg.MaxDelay,
+	}
+}
+
+// Do sends req, retrying retryable failures until maxRetries is exhausted or the
+// request context is cancelled. 4xx responses are returned to the caller as-is.
+func (c *Client) Do(req *http.Request) (*http.Response, error) {
+	log := logger.GetLogger()
+	ctx := req.Context()
+
+	for attempt := 0; ; attempt++ {
+		resp, err := c.http.Do(req)
+		if !shouldRetry(ctx, resp, err) || attempt >= c.maxRetries || !replayable(req) {
+			return resp, err
+		}
+
+		reason := ""
+		if err != nil {
+			reason = err.Error()
+		} else {
+			reason = resp.Status
+			resp.Body.Close()
+		}
+
+		delay := c.backoff(attempt)
+		log.Warnf("Retrying %s %s after %s (attempt %d/%d): %s", req.Method, req.URL.Host, delay, attempt+1, c.maxRetries, reason)
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(delay):
+		}
+
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req = req.Clone(ctx)
+			req.Body = body
+		}
+	}
+}
+
+func (c *Client) backoff(attempt int) time.Duration {
+	delay := c.baseDelay << attempt
+	if delay <= 0 || delay > c.maxDelay {
+		return c.maxDelay
+	}
+	return delay
+}
+
+func shouldRetry(ctx context.Context, resp *http.Response, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	if err != nil {
+		// Only transient transport failures are retried; TLS, DNS and malformed URL
+		// errors also satisfy net.Error but will not succeed on a second attempt
+		if errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return true
+		}
+		var netErr net.Error
+		return errors.As(err, &netErr) && netErr.Timeout()
+	}
+	return resp.StatusCode >= http.StatusInternalServerError
+}
+
+// replayable reports whether req can be sent again after its body was consumed
+func replayable(req *http.Request) bool {
+	return req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+}
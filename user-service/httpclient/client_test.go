@@ -0,0 +1,159 @@
+package httpclient
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/NomadCrew/nomad-crew-backend/user-service/config"
+)
+
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+// step is one scripted transport outcome: a status code or an error
+type step struct {
+	status int
+	err    error
+}
+
+type scriptedTransport struct {
+	steps    []step
+	attempts int
+}
+
+func (t *scriptedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := req.Context().Err(); err != nil {
+		t.attempts++
+		return nil, err
+	}
+	s := t.steps[t.attempts]
+	t.attempts++
+	if s.err != nil {
+		return nil, s.err
+	}
+	return &http.Response{
+		StatusCode: s.status,
+		Status:     http.StatusText(s.status),
+		Body:       io.NopCloser(strings.NewReader("")),
+		Request:    req,
+	}, nil
+}
+
+func TestClientDo(t *testing.T) {
+	tests := []struct {
+		name         string
+		steps        []step
+		cancelled    bool
+		wantAttempts int
+		wantStatus   int
+		wantErr      bool
+	}{
+		{
+			name:         "5xx is retried until success",
+			steps:        []step{{status: 503}, {status: 502}, {status: 200}},
+			wantAttempts: 3,
+			wantStatus:   200,
+		},
+		{
+			name:         "5xx gives up after max retries",
+			steps:        []step{{status: 500}, {status: 500}, {status: 500}, {status: 500}},
+			wantAttempts: 4,
+			wantStatus:   500,
+		},
+		{
+			name:         "4xx is not retried",
+			steps:        []step{{status: 404}},
+			wantAttempts: 1,
+			wantStatus:   404,
+		},
+		{
+			name:         "timeout is retried",
+			steps:        []step{{err: timeoutError{}}, {status: 200}},
+			wantAttempts: 2,
+			wantStatus:   200,
+		},
+		{
+			name:         "connection reset is retried",
+			steps:        []step{{err: syscall.ECONNRESET}, {status: 200}},
+			wantAttempts: 2,
+			wantStatus:   200,
+		},
+		{
+			name:         "unexpected EOF is retried",
+			steps:        []step{{err: io.ErrUnexpectedEOF}, {status: 200}},
+			wantAttempts: 2,
+			wantStatus:   200,
+		},
+		{
+			name:         "TLS certificate error is not retried",
+			steps:        []step{{err: x509.UnknownAuthorityError{}}},
+			wantAttempts: 1,
+			wantErr:      true,
+		},
+		{
+			name:         "non-transient transport error is not retried",
+			steps:        []step{{err: errors.New("unsupported protocol scheme")}},
+			wantAttempts: 1,
+			wantErr:      true,
+		},
+		{
+			name:         "cancelled context is not retried",
+			steps:        []step{{status: 200}},
+			cancelled:    true,
+			wantAttempts: 1,
+			wantErr:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transport := &scriptedTransport{steps: tt.steps}
+			c := New(config.HTTPClientConfig{
+				MaxRetries: 3,
+				BaseDelay:  time.Millisecond,
+				MaxDelay:   5 * time.Millisecond,
+				Timeout:    time.Second,
+			})
+			c.http.Transport = transport
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			if tt.cancelled {
+				cancel()
+			}
+
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://api.example.com/v1", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			resp, err := c.Do(req)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got status %d", resp.StatusCode)
+				}
+			} else {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if resp.StatusCode != tt.wantStatus {
+					t.Errorf("status = %d, want %d", resp.StatusCode, tt.wantStatus)
+				}
+			}
+
+			if transport.attempts != tt.wantAttempts {
+				t.Errorf("attempts = %d, want %d", transport.attempts, tt.wantAttempts)
+			}
+		})
+	}
+}
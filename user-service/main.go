@@ -4,15 +4,26 @@ import (
 	"net/http"
 
 	"github.com/go-chi/chi/v5"
+	chimiddleware "github.com/go-chi/chi/v5/middleware"
+	"github.com/redis/go-redis/v9"
 	"github.com/rs/cors"
+	httpSwagger "github.com/swaggo/http-swagger/v2"
 
 	"github.com/NomadCrew/nomad-crew-backend/user-service/config"
 	"github.com/NomadCrew/nomad-crew-backend/user-service/db"
+	_ "github.com/NomadCrew/nomad-crew-backend/user-service/docs"
 	"github.com/NomadCrew/nomad-crew-backend/user-service/handlers"
+	"github.com/NomadCrew/nomad-crew-backend/user-service/httpclient"
 	"github.com/NomadCrew/nomad-crew-backend/user-service/logger"
 	"github.com/NomadCrew/nomad-crew-backend/user-service/middleware"
 )
 
+// @title NomadCrew User Service API
+// @version 1.0
+// @description User registration, profile and places endpoints for NomadCrew.
+// @securityDefinitions.apikey BearerAuth
+// @in header
+// @name Authorization
 func main() {
 	logger := logger.GetLogger()
 	cfg, err := config.LoadConfig()
@@ -21,19 +32,61 @@ func main() {
 	}
 
 	dbPool := db.ConnectToDB(cfg.DatabaseConnectionString)
-	server := handlers.Server{DB: dbPool}
+	// Redis is a hard dependency once configured; readiness reports it
+	var redisClient *redis.Client
+	if cfg.Redis.Addr != "" {
+		redisClient = db.ConnectToRedis(cfg.Redis.Addr, cfg.Redis.Password)
+	}
+	server := handlers.Server{
+		DB:               dbPool,
+		Redis:            redisClient,
+		HTTPClient:       httpclient.New(cfg.HTTPClient),
+		UpstreamDeadline: cfg.HTTPClient.RequestDeadline,
+	}
 
 	router := chi.NewRouter()
 
+	// CSRF protection is only needed when the web client authenticates with cookies
+	if cfg.CSRFEnabled {
+		router.Use(middleware.CSRFProtect(cfg.CSRFSessionCookie))
+		router.Get("/v1/csrf-token", middleware.CSRFTokenHandler)
+	}
+
+	// Mutating requests are throttled per user (or per IP before authentication)
+	limitWrites := func(next http.Handler) http.Handler { return next }
+	if cfg.RateLimit.WritesPerMinute > 0 {
+		limitWrites = middleware.NewWriteRateLimiter(redisClient, cfg.RateLimit.WritesPerMinute, cfg.RateLimit.Burst, cfg.RateLimit.TrustedProxies).Limit
+	}
+
 	// Public routes
-	router.Post("/v1/register", server.RegisterHandler)
-	router.Post("/v1/login", server.LoginHandler)
+	router.Group(func(r chi.Router) {
+		r.Use(limitWrites)
+		r.Post("/v1/register", server.RegisterHandler)
+		r.Post("/v1/login", server.LoginHandler)
+	})
+
+	// Health probes
+	router.Get("/health", server.HealthHandler)
+	router.Get("/health/live", server.LivenessHandler)
+	router.Get("/health/ready", server.HealthHandler)
+
+	// API docs, generated with `swag init` from the handler annotations
+	if cfg.Swagger.Enabled {
+		router.Group(func(r chi.Router) {
+			if cfg.Swagger.Username != "" {
+				r.Use(chimiddleware.BasicAuth("swagger", map[string]string{cfg.Swagger.Username: cfg.Swagger.Password}))
+			}
+			r.Get("/swagger/*", httpSwagger.Handler(httpSwagger.URL("/swagger/doc.json")))
+		})
+	}
 
 	// Protected routes
 	router.Group(func(r chi.Router) {
 		r.Use(func(next http.Handler) http.Handler {
 			return middleware.EnsureValidToken(next)
 		})
+		// Only GETs today; writes added here are limited per Firebase UID
+		r.Use(limitWrites)
 		// Add your protected routes here
 		r.Get("/v1/user", server.GetUserHandler)
 		r.Get("/v1/nearby-places", server.GetNearbyPlacesHandler)
@@ -42,13 +95,22 @@ func main() {
 	corsHandler := cors.New(cors.Options{
 		AllowedOrigins:   []string{"*"},
 		AllowedMethods:   []string{"GET", "POST", "OPTIONS"},
-		AllowedHeaders:   []string{"Content-Type", "Authorization"},
+		AllowedHeaders:   []string{"Content-Type", "Authorization", "X-CSRF-Token"},
+		ExposedHeaders:   []string{"Retry-After", "X-RateLimit-Limit", "X-RateLimit-Remaining"},
 		AllowCredentials: true,
 		Debug:            true,
 	}).Handler(router)
 
-	// http.ListenAndServe(":"+cfg.Port, corsHandler)
-	err = http.ListenAndServeTLS(":"+cfg.Port, "/etc/nginx/ssl/localhost+2.pem", "/etc/nginx/ssl/localhost+2-key.pem", corsHandler)
+	srv := &http.Server{
+		Addr:         ":" + cfg.Port,
+		Handler:      corsHandler,
+		ReadTimeout:  cfg.Server.ReadTimeout,
+		WriteTimeout: cfg.Server.WriteTimeout,
+		IdleTimeout:  cfg.Server.IdleTimeout,
+	}
+
+	// srv.ListenAndServe()
+	err = srv.ListenAndServeTLS("/etc/nginx/ssl/localhost+2.pem", "/etc/nginx/ssl/localhost+2-key.pem")
 	if err != nil {
 		logger.Fatalf("Failed to start TLS server: %s", err)
 	}
@@ -0,0 +1,83 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+
+	"github.com/NomadCrew/nomad-crew-backend/user-service/errors"
+)
+
+const (
+	csrfCookieName = "csrf_token"
+	csrfHeaderName = "X-CSRF-Token"
+)
+
+// CSRFProtect enforces double-submit CSRF tokens on requests authenticated by the
+// sessionCookie cookie. Bearer-token requests and requests without that cookie are
+// passed through untouched, so unrelated cookies (analytics etc.) never trigger it.
+func CSRFProtect(sessionCookie string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.Method {
+			case http.MethodGet, http.MethodHead, http.MethodOptions:
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if extractBearerToken(r.Header.Get("Authorization")) != "" || !hasCookie(r, sessionCookie) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			cookie, err := r.Cookie(csrfCookieName)
+			if err != nil || cookie.Value == "" {
+				errors.WriteError(w, errors.Forbidden("Missing CSRF token", ""))
+				return
+			}
+
+			header := r.Header.Get(csrfHeaderName)
+			if subtle.ConstantTimeCompare([]byte(cookie.Value), []byte(header)) != 1 {
+				errors.WriteError(w, errors.Forbidden("Invalid CSRF token", ""))
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func hasCookie(r *http.Request, name string) bool {
+	cookie, err := r.Cookie(name)
+	return err == nil && cookie.Value != ""
+}
+
+// CSRFTokenHandler issues a fresh CSRF token as a cookie and echoes it in the body
+// so the web client can send it back in the X-CSRF-Token header.
+// @Summary Issue CSRF token
+// @Tags auth
+// @Produce json
+// @Success 200 {object} map[string]string
+// @Router /v1/csrf-token [get]
+func CSRFTokenHandler(w http.ResponseWriter, r *http.Request) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		errors.WriteError(w, errors.InternalServerError("Failed to generate CSRF token"))
+		return
+	}
+	token := base64.RawURLEncoding.EncodeToString(buf)
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     csrfCookieName,
+		Value:    token,
+		Path:     "/",
+		HttpOnly: true,
+		Secure:   true,
+		SameSite: http.SameSiteStrictMode,
+	})
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"csrfToken": token})
+}
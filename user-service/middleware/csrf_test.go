@@ -0,0 +1,91 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCSRFProtect(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		bearer     bool
+		cookies    map[string]string
+		header     string
+		wantStatus int
+	}{
+		{
+			name:       "bearer request skips check",
+			method:     http.MethodPost,
+			bearer:     true,
+			cookies:    map[string]string{"session": "abc"},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "no cookies skips check",
+			method:     http.MethodPost,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "unrelated cookie skips check",
+			method:     http.MethodPost,
+			cookies:    map[string]string{"_ga": "GA1.1.123"},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "session cookie with matching header",
+			method:     http.MethodPost,
+			cookies:    map[string]string{"session": "abc", csrfCookieName: "token"},
+			header:     "token",
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "session cookie with mismatched header",
+			method:     http.MethodPost,
+			cookies:    map[string]string{"session": "abc", csrfCookieName: "token"},
+			header:     "other",
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "session cookie without csrf cookie",
+			method:     http.MethodPost,
+			cookies:    map[string]string{"session": "abc"},
+			header:     "token",
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "safe method skips check",
+			method:     http.MethodGet,
+			cookies:    map[string]string{"session": "abc", csrfCookieName: "token"},
+			header:     "other",
+			wantStatus: http.StatusOK,
+		},
+	}
+
+	handler := CSRFProtect("session")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, "/v1/register", nil)
+			if tt.bearer {
+				req.Header.Set("Authorization", "Bearer some-id-token")
+			}
+			for name, value := range tt.cookies {
+				req.AddCookie(&http.Cookie{Name: name, Value: value})
+			}
+			if tt.header != "" {
+				req.Header.Set(csrfHeaderName, tt.header)
+			}
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+		})
+	}
+}
@@ -2,21 +2,34 @@ package middleware
 
 import (
 	"context"
-	"fmt"
 	"net/http"
 	"strings"
 
 	firebase "firebase.google.com/go"
 	"google.golang.org/api/option"
+
+	"github.com/NomadCrew/nomad-crew-backend/user-service/errors"
+	"github.com/NomadCrew/nomad-crew-backend/user-service/logger"
 )
 
+type contextKey string
+
+const uidKey contextKey = "uid"
+
+// UserIDFromContext returns the Firebase UID stored by EnsureValidToken
+func UserIDFromContext(ctx context.Context) (string, bool) {
+	uid, ok := ctx.Value(uidKey).(string)
+	return uid, ok && uid != ""
+}
+
 // EnsureValidToken verifies the Firebase ID token
 func EnsureValidToken(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		log := logger.GetLogger()
 		// Extract the token from the Authorization header
 		idToken := extractBearerToken(r.Header.Get("Authorization"))
 		if idToken == "" {
-			http.Error(w, "Missing or malformed JWT", http.StatusUnauthorized)
+			errors.WriteError(w, errors.Unauthorized("Missing or malformed JWT", ""))
 			return
 		}
 
@@ -24,26 +37,27 @@ func EnsureValidToken(next http.Handler) http.Handler {
 		opt := option.WithCredentialsFile("/secrets/serviceAccountKey.json")
 		app, err := firebase.NewApp(context.Background(), nil, opt)
 		if err != nil {
-			http.Error(w, fmt.Sprintf("error initializing app: %v", err), http.StatusInternalServerError)
+			log.Errorf("error initializing app: %v", err)
+			errors.WriteError(w, errors.InternalServerError("Failed to initialize authentication"))
 			return
 		}
 
 		// Authenticate the token with Firebase
 		client, err := app.Auth(context.Background())
 		if err != nil {
-			http.Error(w, fmt.Sprintf("error getting Auth client: %v", err), http.StatusInternalServerError)
+			log.Errorf("error getting Auth client: %v", err)
+			errors.WriteError(w, errors.InternalServerError("Failed to initialize authentication"))
 			return
 		}
 
 		token, err := client.VerifyIDToken(context.Background(), idToken)
 		if err != nil {
-			http.Error(w, fmt.Sprintf("error verifying ID token: %v", err), http.StatusUnauthorized)
+			log.Warnf("error verifying ID token: %v", err)
+			errors.WriteError(w, errors.Unauthorized("Invalid ID token", ""))
 			return
 		}
 
 		// Pass the user's Firebase UID to the next middleware or handler
-		type contextKey string
-		const uidKey contextKey = "uid"
 		ctx := context.WithValue(r.Context(), uidKey, token.UID)
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
@@ -0,0 +1,129 @@
+package middleware
+
+import (
+	"net"
+	"net/http"
+	"strconv"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/NomadCrew/nomad-crew-backend/user-service/errors"
+	"github.com/NomadCrew/nomad-crew-backend/user-service/logger"
+)
+
+const rateLimitKeyPrefix = "ratelimit:write:"
+
+// tokenBucketScript refills and takes one token atomically, using the Redis
+// clock so every instance shares the same bucket state. It returns
+// {allowed, remainingTokens, retryAfterSeconds}.
+var tokenBucketScript = redis.NewScript(`
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local t = redis.call('TIME')
+local now = tonumber(t[1]) + tonumber(t[2]) / 1000000
+
+local state = redis.call('HMGET', KEYS[1], 'tokens', 'ts')
+local tokens = tonumber(state[1])
+local ts = tonumber(state[2])
+if tokens == nil or ts == nil then
+	tokens = burst
+	ts = now
+end
+tokens = math.min(burst, tokens + math.max(0, now - ts) * rate)
+
+local allowed = 0
+local retry_after = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+else
+	retry_after = math.ceil((1 - tokens) / rate)
+end
+
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'ts', tostring(now))
+redis.call('EXPIRE', KEYS[1], math.ceil(burst / rate) + 1)
+return {allowed, math.floor(tokens), retry_after}
+`)
+
+// WriteRateLimiter throttles mutating requests with a Redis-backed token bucket per
+// client, shared across instances. Authenticated requests are keyed by Firebase UID,
+// anonymous ones by client IP.
+type WriteRateLimiter struct {
+	redis          *redis.Client
+	perMinute      int
+	burst          int
+	trustedProxies map[string]bool
+}
+
+// NewWriteRateLimiter allows perMinute writes per client with bursts of up to burst.
+// X-Real-IP is only trusted on connections from trustedProxies.
+func NewWriteRateLimiter(client *redis.Client, perMinute, burst int, trustedProxies []string) *WriteRateLimiter {
+	proxies := make(map[string]bool, len(trustedProxies))
+	for _, ip := range trustedProxies {
+		proxies[ip] = true
+	}
+	return &WriteRateLimiter{
+		redis:          client,
+		perMinute:      perMinute,
+		burst:          burst,
+		trustedProxies: proxies,
+	}
+}
+
+// Limit rejects writes over the limit with 429 and a Retry-After header. Every
+// limited response reports the bucket size and the writes left in
+// X-RateLimit-Limit and X-RateLimit-Remaining.
+// Safe methods are never limited. If Redis is unreachable the request is let through.
+func (l *WriteRateLimiter) Limit(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		log := logger.GetLogger()
+		key := l.clientKey(r)
+
+		rate := float64(l.perMinute) / 60
+		result, err := tokenBucketScript.Run(r.Context(), l.redis, []string{rateLimitKeyPrefix + key}, rate, l.burst).Int64Slice()
+		if err != nil || len(result) != 3 {
+			log.Errorf("Write rate limiter unavailable, allowing request: %v", err)
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		w.Header().Set("X-RateLimit-Limit", strconv.Itoa(l.burst))
+		w.Header().Set("X-RateLimit-Remaining", strconv.FormatInt(result[1], 10))
+		if result[0] != 1 {
+			retryAfter := result[2]
+			if retryAfter < 1 {
+				retryAfter = 1
+			}
+			log.Warnf("Write rate limit exceeded for %s on %s %s", key, r.Method, r.URL.Path)
+			w.Header().Set("Retry-After", strconv.FormatInt(retryAfter, 10))
+			errors.WriteError(w, errors.RateLimited("Too many requests, please try again later"))
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (l *WriteRateLimiter) clientKey(r *http.Request) string {
+	if uid, ok := UserIDFromContext(r.Context()); ok {
+		return "user:" + uid
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	// Only a trusted proxy (nginx) may tell us the original client address
+	if l.trustedProxies[host] {
+		if ip := r.Header.Get("X-Real-IP"); ip != "" {
+			return "ip:" + ip
+		}
+	}
+	return "ip:" + host
+}
@@ -0,0 +1,122 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+func newTestLimiter(t *testing.T, perMinute, burst int) http.Handler {
+	t.Helper()
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	limiter := NewWriteRateLimiter(client, perMinute, burst, []string{"127.0.0.1"})
+	return limiter.Limit(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+}
+
+func postFrom(handler http.Handler, remoteAddr, realIP string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/v1/register", nil)
+	req.RemoteAddr = remoteAddr
+	if realIP != "" {
+		req.Header.Set("X-Real-IP", realIP)
+	}
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestWriteRateLimiterBurst(t *testing.T) {
+	handler := newTestLimiter(t, 60, 2)
+
+	for i := 0; i < 2; i++ {
+		rec := postFrom(handler, "203.0.113.5:1234", "")
+		if rec.Code != http.StatusOK {
+			t.Fatalf("request %d: status = %d, want 200", i, rec.Code)
+		}
+		if got, want := rec.Header().Get("X-RateLimit-Remaining"), strconv.Itoa(1-i); got != want {
+			t.Errorf("request %d: X-RateLimit-Remaining = %q, want %q", i, got, want)
+		}
+	}
+
+	rec := postFrom(handler, "203.0.113.5:1234", "")
+	if rec.Code != http.StatusTooManyRequests {
+		t.Fatalf("status = %d, want 429", rec.Code)
+	}
+	if got := rec.Header().Get("X-RateLimit-Limit"); got != "2" {
+		t.Errorf("X-RateLimit-Limit = %q, want the burst size 2", got)
+	}
+	if got := rec.Header().Get("X-RateLimit-Remaining"); got != "0" {
+		t.Errorf("X-RateLimit-Remaining = %q, want 0", got)
+	}
+	retryAfter, err := strconv.Atoi(rec.Header().Get("Retry-After"))
+	if err != nil || retryAfter < 1 || retryAfter > 60 {
+		t.Errorf("Retry-After = %q, want between 1 and 60 seconds", rec.Header().Get("Retry-After"))
+	}
+
+	// Reads are never limited
+	req := httptest.NewRequest(http.MethodGet, "/v1/user", nil)
+	req.RemoteAddr = "203.0.113.5:1234"
+	getRec := httptest.NewRecorder()
+	handler.ServeHTTP(getRec, req)
+	if getRec.Code != http.StatusOK {
+		t.Errorf("GET status = %d, want 200", getRec.Code)
+	}
+}
+
+func TestWriteRateLimiterIgnoresSpoofedRealIP(t *testing.T) {
+	handler := newTestLimiter(t, 60, 1)
+
+	if rec := postFrom(handler, "203.0.113.5:1234", "198.51.100.1"); rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", rec.Code)
+	}
+	// A direct client rotating X-Real-IP must still share its RemoteAddr bucket
+	if rec := postFrom(handler, "203.0.113.5:1234", "198.51.100.2"); rec.Code != http.StatusTooManyRequests {
+		t.Fatalf("status = %d, want 429", rec.Code)
+	}
+}
+
+func TestWriteRateLimiterTrustsProxyRealIP(t *testing.T) {
+	handler := newTestLimiter(t, 60, 1)
+
+	if rec := postFrom(handler, "127.0.0.1:5555", "198.51.100.1"); rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", rec.Code)
+	}
+	// Behind nginx, different clients get separate buckets
+	if rec := postFrom(handler, "127.0.0.1:5555", "198.51.100.2"); rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", rec.Code)
+	}
+	if rec := postFrom(handler, "127.0.0.1:5555", "198.51.100.1"); rec.Code != http.StatusTooManyRequests {
+		t.Fatalf("status = %d, want 429", rec.Code)
+	}
+}
+
+func TestWriteRateLimiterKeysByUID(t *testing.T) {
+	handler := newTestLimiter(t, 60, 1)
+	postAs := func(uid, remoteAddr string) int {
+		req := httptest.NewRequest(http.MethodPost, "/v1/trips", nil)
+		req.RemoteAddr = remoteAddr
+		req = req.WithContext(context.WithValue(req.Context(), uidKey, uid))
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	if code := postAs("alice", "203.0.113.5:1234"); code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", code)
+	}
+	// The same user is limited even after switching networks
+	if code := postAs("alice", "198.51.100.7:4321"); code != http.StatusTooManyRequests {
+		t.Fatalf("status = %d, want 429", code)
+	}
+	// Other users behind the same address keep their own bucket
+	if code := postAs("bob", "203.0.113.5:1234"); code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", code)
+	}
+}